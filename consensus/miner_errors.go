@@ -0,0 +1,84 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package consensus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/usechain/go-usechain/common"
+)
+
+// This file is deliberately named miner_errors.go rather than errors.go:
+// core/block_validator.go also references consensus.ErrUnknownAncestor and
+// consensus.ErrPrunedAncestor, which belong in this package but aren't
+// defined anywhere in this tree, so a generically-named errors.go here
+// would risk colliding with wherever those end up landing.
+//
+// Sentinel errors returned by BlockValidator.ValidateMiner. Callers should
+// compare against these with errors.Is (MinerValidationError unwraps to
+// one of them) instead of matching on error text.
+var (
+	ErrBlockIntervalTooSmall  = errors.New("block time slot should be more than five seconds")
+	ErrPunishedMiner          = errors.New("miner address is being punished")
+	ErrUnregisteredMiner      = errors.New("miner address needs to register as a miner")
+	ErrInvalidMinerQr         = errors.New("invalid miner qr signature")
+	ErrInvalidMiner           = errors.New("invalid miner")
+	ErrInvalidPrimaryMiner    = errors.New("invalid primary miner")
+	ErrDifficultyLevelTooHigh = errors.New("invalid difficulty level")
+)
+
+// MinerValidationReason enumerates the distinct ways ValidateMiner can
+// reject a block's miner, so a PunishmentReporter can react to the
+// specific failure mode instead of string-matching error text.
+type MinerValidationReason int
+
+const (
+	ReasonBlockIntervalTooSmall MinerValidationReason = iota
+	ReasonPunishedMiner
+	ReasonUnregisteredMiner
+	ReasonInvalidMinerQr
+	ReasonInvalidPrimaryMiner
+	ReasonDifficultyLevelTooHigh
+	ReasonInvalidMiner
+)
+
+// MinerValidationError wraps one of the sentinel errors above with the
+// offending coinbase and block number, so a PunishmentReporter doesn't
+// need to re-derive them from the block.
+type MinerValidationError struct {
+	Reason   MinerValidationReason
+	Coinbase common.Address
+	Number   uint64
+	Err      error
+}
+
+func (e *MinerValidationError) Error() string {
+	return fmt.Sprintf("miner %s at block %d: %v", e.Coinbase.Hex(), e.Number, e.Err)
+}
+
+func (e *MinerValidationError) Unwrap() error {
+	return e.Err
+}
+
+// PunishmentReporter is notified whenever ValidateMiner rejects a block's
+// miner. It lets downstream components such as fetcher/downloader peer
+// scoring, the miner list contract's punishment counter, and metrics react
+// without string-matching error text.
+type PunishmentReporter interface {
+	ReportMinerValidationFailure(*MinerValidationError)
+}
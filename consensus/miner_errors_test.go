@@ -0,0 +1,93 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package consensus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/usechain/go-usechain/common"
+)
+
+func TestMinerValidationErrorUnwrapsToSentinel(t *testing.T) {
+	tests := []struct {
+		reason   MinerValidationReason
+		sentinel error
+	}{
+		{ReasonBlockIntervalTooSmall, ErrBlockIntervalTooSmall},
+		{ReasonPunishedMiner, ErrPunishedMiner},
+		{ReasonUnregisteredMiner, ErrUnregisteredMiner},
+		{ReasonInvalidMinerQr, ErrInvalidMinerQr},
+		{ReasonInvalidPrimaryMiner, ErrInvalidPrimaryMiner},
+		{ReasonDifficultyLevelTooHigh, ErrDifficultyLevelTooHigh},
+		{ReasonInvalidMiner, ErrInvalidMiner},
+	}
+	for _, tt := range tests {
+		mverr := &MinerValidationError{
+			Reason:   tt.reason,
+			Coinbase: common.BytesToAddress([]byte("miner")),
+			Number:   42,
+			Err:      tt.sentinel,
+		}
+		if !errors.Is(mverr, tt.sentinel) {
+			t.Errorf("reason %v: errors.Is(mverr, %v) = false, want true", tt.reason, tt.sentinel)
+		}
+		for _, other := range tests {
+			if other.sentinel == tt.sentinel {
+				continue
+			}
+			if errors.Is(mverr, other.sentinel) {
+				t.Errorf("reason %v: errors.Is(mverr, %v) = true, want false", tt.reason, other.sentinel)
+			}
+		}
+	}
+}
+
+type recordingReporter struct {
+	got *MinerValidationError
+}
+
+func (r *recordingReporter) ReportMinerValidationFailure(err *MinerValidationError) {
+	r.got = err
+}
+
+func TestPunishmentReporterReceivesMinerValidationError(t *testing.T) {
+	coinbase := common.BytesToAddress([]byte("offending-miner"))
+	mverr := &MinerValidationError{
+		Reason:   ReasonPunishedMiner,
+		Coinbase: coinbase,
+		Number:   7,
+		Err:      ErrPunishedMiner,
+	}
+
+	reporter := &recordingReporter{}
+	var r PunishmentReporter = reporter
+	r.ReportMinerValidationFailure(mverr)
+
+	if reporter.got == nil {
+		t.Fatalf("expected the reporter to have been invoked")
+	}
+	if reporter.got.Reason != ReasonPunishedMiner {
+		t.Errorf("Reason = %v, want %v", reporter.got.Reason, ReasonPunishedMiner)
+	}
+	if reporter.got.Coinbase != coinbase {
+		t.Errorf("Coinbase = %v, want %v", reporter.got.Coinbase, coinbase)
+	}
+	if reporter.got.Number != 7 {
+		t.Errorf("Number = %v, want 7", reporter.got.Number)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package blobpool stores full EIP-4844 blob sidecars for pending
+// transactions. Blocks only ever carry the stripped transaction, so the
+// sidecar has to be looked up here (or recovered from limbo) whenever it
+// needs to be re-broadcast or re-validated.
+package blobpool
+
+import (
+	"sync"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/core/types"
+)
+
+// limboSlots is the number of blocks a sidecar is retained in limbo after
+// its transaction is included, long enough to survive a typical reorg.
+const limboSlots = 32
+
+type limboEntry struct {
+	sidecar *types.BlobTxSidecar
+	block   uint64
+}
+
+// BlobPool indexes blob sidecars by transaction hash. Pending entries back
+// transactions that have not been included yet; limbo entries back
+// transactions that were included recently enough that a reorg could still
+// put them back in the mempool.
+type BlobPool struct {
+	mu      sync.RWMutex
+	pending map[common.Hash]*types.BlobTxSidecar
+	limbo   map[common.Hash]limboEntry
+}
+
+// New returns an empty BlobPool.
+func New() *BlobPool {
+	return &BlobPool{
+		pending: make(map[common.Hash]*types.BlobTxSidecar),
+		limbo:   make(map[common.Hash]limboEntry),
+	}
+}
+
+// Add stores the sidecar for a pending blob transaction.
+func (p *BlobPool) Add(hash common.Hash, sidecar *types.BlobTxSidecar) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[hash] = sidecar
+}
+
+// Get returns the sidecar for a pending or limbo transaction, or nil if
+// none is held.
+func (p *BlobPool) Get(hash common.Hash) *types.BlobTxSidecar {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if sidecar, ok := p.pending[hash]; ok {
+		return sidecar
+	}
+	if entry, ok := p.limbo[hash]; ok {
+		return entry.sidecar
+	}
+	return nil
+}
+
+// Included moves a transaction's sidecar from pending into limbo, keyed off
+// the block it was included in.
+func (p *BlobPool) Included(hash common.Hash, block uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sidecar, ok := p.pending[hash]
+	if !ok {
+		return
+	}
+	delete(p.pending, hash)
+	p.limbo[hash] = limboEntry{sidecar: sidecar, block: block}
+}
+
+// Reorged re-admits a transaction's sidecar to the pending set if it is
+// still held in limbo, so the transaction can be rebroadcast with its
+// blobs intact. It reports false if the sidecar already aged out of limbo,
+// in which case the transaction cannot re-enter the pool without a fresh
+// sidecar being supplied from outside.
+func (p *BlobPool) Reorged(hash common.Hash) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.limbo[hash]
+	if !ok {
+		return false
+	}
+	delete(p.limbo, hash)
+	p.pending[hash] = entry.sidecar
+	return true
+}
+
+// Shift evicts limbo entries older than limboSlots blocks behind head.
+func (p *BlobPool) Shift(head uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for hash, entry := range p.limbo {
+		if head > entry.block+limboSlots {
+			delete(p.limbo, hash)
+		}
+	}
+}
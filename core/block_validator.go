@@ -21,10 +21,13 @@ import (
 	"fmt"
 	"github.com/usechain/go-usechain/log"
 	"math/big"
+	"runtime"
+	"sync"
 
 	"github.com/usechain/go-usechain/common"
 	"github.com/usechain/go-usechain/consensus"
 	"github.com/usechain/go-usechain/contracts/minerlist"
+	"github.com/usechain/go-usechain/core/blobpool"
 	"github.com/usechain/go-usechain/core/state"
 	"github.com/usechain/go-usechain/core/types"
 	"github.com/usechain/go-usechain/crypto"
@@ -36,9 +39,11 @@ import (
 //
 // BlockValidator implements Validator.
 type BlockValidator struct {
-	config *params.ChainConfig // Chain configuration options
-	bc     *BlockChain         // Canonical block chain
-	engine consensus.Engine    // Consensus engine used for validating
+	config   *params.ChainConfig          // Chain configuration options
+	bc       *BlockChain                  // Canonical block chain
+	engine   consensus.Engine             // Consensus engine used for validating
+	punisher consensus.PunishmentReporter // Notified of ValidateMiner failures, if registered
+	blobs    *blobpool.BlobPool           // Pools blob sidecars across inclusion/reorg, if registered
 }
 
 // NewBlockValidator returns a new block validator which is safe for re-use
@@ -51,6 +56,23 @@ func NewBlockValidator(config *params.ChainConfig, blockchain *BlockChain, engin
 	return validator
 }
 
+// RegisterPunishmentReporter wires a PunishmentReporter that is notified of
+// every miner validation failure ValidateMiner encounters.
+func (v *BlockValidator) RegisterPunishmentReporter(r consensus.PunishmentReporter) {
+	v.punisher = r
+}
+
+// reportMinerFailure builds the MinerValidationError for a ValidateMiner
+// rejection, notifies the registered PunishmentReporter if any, and
+// returns the error for the caller to propagate.
+func (v *BlockValidator) reportMinerFailure(reason consensus.MinerValidationReason, coinbase common.Address, number uint64, err error) error {
+	mverr := &consensus.MinerValidationError{Reason: reason, Coinbase: coinbase, Number: number, Err: err}
+	if v.punisher != nil {
+		v.punisher.ReportMinerValidationFailure(mverr)
+	}
+	return mverr
+}
+
 // ValidateBody validates the given block's uncles and verifies the the block
 // header's transaction and uncle roots. The headers are assumed to be already
 // validated at this point.
@@ -66,17 +88,112 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 		return consensus.ErrPrunedAncestor
 	}
 	// Header validity is known at this point, check the uncles and transactions
-	header := block.Header()
 	state, _ := v.bc.State()
 	if err := v.engine.VerifyUncles(v.bc, block, state); err != nil {
 		return err
 	}
+	return v.validateBodyContent(block)
+}
+
+// validateBodyContent checks the parts of ValidateBody that depend only on
+// the block's own contents - uncle root, transaction root, blob sidecar
+// stripping, and (via validateBlobSidecars) the KZG/versioned-hash checks
+// ValidateBlobs performs against the sidecars the registered BlobPool
+// stashed - without consulting v.bc/v.engine for chain membership or
+// consensus-level uncle verification. ValidateBody calls this after its
+// chain-membership checks; ValidatePipeline's body stage calls it
+// directly, since a pipeline segment's contiguity is already guaranteed
+// by construction rather than by re-deriving it from v.bc.
+func (v *BlockValidator) validateBodyContent(block *types.Block) error {
+	header := block.Header()
 	if hash := types.CalcUncleHash(block.Uncles()); hash != header.UncleHash {
 		return fmt.Errorf("uncle root hash mismatch: have %x, want %x", hash, header.UncleHash)
 	}
 	if hash := types.DeriveSha(block.Transactions()); hash != header.TxHash {
 		return fmt.Errorf("transaction root hash mismatch: have %x, want %x", hash, header.TxHash)
 	}
+	// Blocks only ever propagate the stripped form of a blob transaction;
+	// a sidecar still attached here means it was never removed before the
+	// block was built, or was reattached somewhere along the way.
+	for _, tx := range block.Transactions() {
+		if tx.BlobTxSidecar() != nil {
+			return fmt.Errorf("transaction %x retains blob sidecar in imported block", tx.Hash())
+		}
+	}
+	return v.validateBlobSidecars(block)
+}
+
+// validateBlobSidecars looks up the sidecar the registered BlobPool stashed
+// for every blob-carrying transaction in block and runs ValidateBlobs
+// against them. It is a no-op if no BlobPool has been registered: a
+// stripped blob transaction then passes body validation without its
+// versioned hashes or KZG proofs being checked, and only transactions that
+// still carry a sidecar are rejected, by the check in validateBodyContent.
+func (v *BlockValidator) validateBlobSidecars(block *types.Block) error {
+	if v.blobs == nil {
+		return nil
+	}
+	sidecars := make(map[common.Hash]*types.BlobTxSidecar)
+	for _, tx := range block.Transactions() {
+		if len(tx.BlobVersionedHashes()) == 0 {
+			continue
+		}
+		sidecar := v.blobs.Get(tx.Hash())
+		if sidecar == nil {
+			return fmt.Errorf("missing blob sidecar for transaction %x", tx.Hash())
+		}
+		sidecars[tx.Hash()] = sidecar
+	}
+	if len(sidecars) == 0 {
+		return nil
+	}
+	return v.ValidateBlobs(block, sidecars)
+}
+
+// maxBlobsPerBlock bounds the total number of blobs any single block may
+// carry, mirroring the MAX_BLOB_GAS_PER_BLOCK limit from EIP-4844.
+const maxBlobsPerBlock = 6
+
+// ValidateBlobs recomputes the versioned hash for every blob of every
+// blob-carrying transaction in the block from the matching sidecar's KZG
+// commitments, checks them against the hashes the transaction itself
+// declares, verifies the accompanying KZG proofs, and caps the total
+// number of blobs in the block. Sidecars are looked up by transaction hash
+// since the block body itself never carries them.
+func (v *BlockValidator) ValidateBlobs(block *types.Block, sidecars map[common.Hash]*types.BlobTxSidecar) error {
+	var blobs int
+	for _, tx := range block.Transactions() {
+		hashes := tx.BlobVersionedHashes()
+		if len(hashes) == 0 {
+			continue
+		}
+		sidecar, ok := sidecars[tx.Hash()]
+		if !ok {
+			return fmt.Errorf("missing blob sidecar for transaction %x", tx.Hash())
+		}
+		if len(sidecar.Commitments) != len(hashes) || len(sidecar.Blobs) != len(hashes) || len(sidecar.Proofs) != len(hashes) {
+			return types.ErrMismatchedBlobSidecar
+		}
+		// Check the cumulative cap before doing any per-blob crypto work, so
+		// a transaction declaring far more than the limit can't force a
+		// full round of KZG proof verification before being rejected.
+		blobs += len(hashes)
+		if blobs > maxBlobsPerBlock {
+			return fmt.Errorf("block contains %d blobs, exceeding limit of %d", blobs, maxBlobsPerBlock)
+		}
+		for i, commitment := range sidecar.Commitments {
+			if got := types.VersionedHash(commitment); got != hashes[i] {
+				return fmt.Errorf("blob %d versioned hash mismatch: have %x, want %x", i, got, hashes[i])
+			}
+			ok, err := kzgVerifyProof(sidecar.Blobs[i], commitment, sidecar.Proofs[i])
+			if err != nil {
+				return fmt.Errorf("verify KZG proof for blob %d of transaction %x: %w", i, tx.Hash(), err)
+			}
+			if !ok {
+				return fmt.Errorf("invalid KZG proof for blob %d of transaction %x", i, tx.Hash())
+			}
+		}
+	}
 	return nil
 }
 
@@ -115,7 +232,7 @@ func (v *BlockValidator) ValidateMiner(block, parent *types.Block, statedb *stat
 	tstampSub := new(big.Int).Sub(tstampHead, tstampParent)
 
 	if tstampSub.Int64() < int64(common.BlockInterval) {
-		return fmt.Errorf("Block time slot should be more than five seconds")
+		return v.reportMinerFailure(consensus.ReasonBlockIntervalTooSmall, header.Coinbase, header.Number.Uint64(), consensus.ErrBlockIntervalTooSmall)
 	}
 
 	totalMinerNum := minerlist.ReadMinerNum(statedb)
@@ -124,10 +241,9 @@ func (v *BlockValidator) ValidateMiner(block, parent *types.Block, statedb *stat
 	isMiner, flag := minerlist.IsMiner(statedb, header.Coinbase, totalMinerNum, header.Number)
 	if !isMiner {
 		if flag == 1 {
-			return fmt.Errorf("miner address is being punished, invalid miner")
-		} else {
-			return fmt.Errorf("miner address needs to register as a miner, invalid miner")
+			return v.reportMinerFailure(consensus.ReasonPunishedMiner, header.Coinbase, header.Number.Uint64(), consensus.ErrPunishedMiner)
 		}
+		return v.reportMinerFailure(consensus.ReasonUnregisteredMiner, header.Coinbase, header.Number.Uint64(), consensus.ErrUnregisteredMiner)
 	}
 
 	preCoinbase := parent.Coinbase()
@@ -145,20 +261,20 @@ func (v *BlockValidator) ValidateMiner(block, parent *types.Block, statedb *stat
 
 	if header.Number.Int64() > 1 {
 		if len(minerQrSignature) != minerlist.PreQrLength {
-			return fmt.Errorf("invalid minerQrSignature length")
+			return v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: bad length", consensus.ErrInvalidMinerQr))
 		}
 		qrtemp := common.BytesToHash(minerQrSignature[65:])
 		if qr.String() != qrtemp.String() {
-			return fmt.Errorf("invalid minerQrSignature, qr is not correct")
+			return v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: qr is not correct", consensus.ErrInvalidMinerQr))
 		}
 
 		if !VerifySig(minerQrSignature[:65], qr, header.Coinbase) {
-			return fmt.Errorf("invalid minerQrSignature")
+			return v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: bad signature", consensus.ErrInvalidMinerQr))
 		}
 	}
 	IsValidMiner, level, preMinerid := minerlist.IsValidMiner(statedb, header.Coinbase, preCoinbase, preQrSignature, blockNumber, totalMinerNum, n)
 	if !IsValidMiner {
-		return fmt.Errorf("invalid miner")
+		return v.reportMinerFailure(consensus.ReasonInvalidMiner, header.Coinbase, header.Number.Uint64(), consensus.ErrInvalidMiner)
 	}
 
 	// Verify PrimaryMiner and DifficultyLevel
@@ -167,16 +283,19 @@ func (v *BlockValidator) ValidateMiner(block, parent *types.Block, statedb *stat
 		preMiner = common.BytesToAddress(minerlist.ReadMinerAddress(statedb, preMinerid))
 	}
 	if bytes.Compare(header.PrimaryMiner.Bytes(), preMiner.Bytes()) != 0 && totalMinerNum.Int64() != 0 {
-		return fmt.Errorf("invalid primaryMiner: have %s, want %s", header.PrimaryMiner.String(), preMiner.String())
+		return v.reportMinerFailure(consensus.ReasonInvalidPrimaryMiner, header.Coinbase, header.Number.Uint64(),
+			fmt.Errorf("%w: have %s, want %s", consensus.ErrInvalidPrimaryMiner, header.PrimaryMiner.String(), preMiner.String()))
 	}
 
 	if header.Number.Cmp(common.Big1) == 0 {
 		if header.DifficultyLevel.Int64() != 0 {
-			return fmt.Errorf("invalid difficultyLevel: have %v, want 0", header.DifficultyLevel)
+			return v.reportMinerFailure(consensus.ReasonDifficultyLevelTooHigh, header.Coinbase, header.Number.Uint64(),
+				fmt.Errorf("%w: have %v, want 0", consensus.ErrDifficultyLevelTooHigh, header.DifficultyLevel))
 		}
 	} else {
 		if level > header.DifficultyLevel.Int64() {
-			return fmt.Errorf("invalid difficultyLevel: have %v, want %v", header.DifficultyLevel, level)
+			return v.reportMinerFailure(consensus.ReasonDifficultyLevelTooHigh, header.Coinbase, header.Number.Uint64(),
+				fmt.Errorf("%w: have %v, want %v", consensus.ErrDifficultyLevelTooHigh, header.DifficultyLevel, level))
 		}
 	}
 
@@ -214,14 +333,112 @@ func CalcGasLimit(parent *types.Block) uint64 {
 	return limit
 }
 
+// sigCache holds miner qr signatures already recovered by VerifySig, so
+// re-validating a signature seen earlier in the same chain segment (or
+// preloaded by the header downloader) skips the ecrecover call.
+var sigCache = NewSignatureCache(8192)
+
+// PreloadMinerSig speculatively populates the signature cache with a
+// recovered address, so the header downloader can warm the cache as soon
+// as a header's MinerQrSignature is available, ahead of ValidateMiner.
+func PreloadMinerSig(hash common.Hash, sig []byte, miner common.Address) {
+	sigCache.Add(hash, sig, miner)
+}
+
+// PreloadHeaderSig recovers and caches the signer of header's miner qr
+// signature as soon as the header is available. The header downloader
+// calls this the moment a header arrives, well ahead of the block itself
+// reaching ValidateMiner/BatchVerifyMinerSigs.
+func (v *BlockValidator) PreloadHeaderSig(header *types.Header) {
+	if header.Number.Int64() <= 1 || len(header.MinerQrSignature) != minerlist.PreQrLength {
+		return
+	}
+	sig := header.MinerQrSignature[:65]
+	qr := common.BytesToHash(header.MinerQrSignature[65:])
+
+	pub, err := crypto.Ecrecover(qr.Bytes(), sig)
+	if err != nil {
+		return
+	}
+	if !crypto.VerifySignature(pub, qr.Bytes(), sig[:64]) {
+		return
+	}
+	PreloadMinerSig(qr, sig, crypto.PubkeyToAddress(*crypto.ToECDSAPub(pub)))
+}
+
+// PurgeSignatureCache evicts every cached signature recovery. Called on a
+// reorg, since cached recoveries keyed off the abandoned side of the
+// chain are no longer useful.
+func PurgeSignatureCache() {
+	sigCache.Purge()
+}
+
+// SignatureCacheHitRate reports the fraction of signature lookups that
+// were served from sigCache rather than falling through to ecrecover.
+func SignatureCacheHitRate() float64 {
+	return sigCache.HitRate()
+}
+
 // verify the qrSignature legality
 // need to verify the sig legality and singer must equal to miner
 func VerifySig(sig []byte, hash common.Hash, miner common.Address) bool {
+	if cached, ok := sigCache.Get(hash, sig); ok {
+		return cached == miner
+	}
 	pub, err := crypto.Ecrecover(hash.Bytes(), sig)
 	if err != nil {
 		log.Error("retrieve public key failed")
 		return false
 	}
 	pubKey := crypto.ToECDSAPub(pub)
-	return crypto.VerifySignature(pub, hash.Bytes(), sig[:64]) && (crypto.PubkeyToAddress(*pubKey) == miner)
+	if !crypto.VerifySignature(pub, hash.Bytes(), sig[:64]) {
+		return false
+	}
+	recovered := crypto.PubkeyToAddress(*pubKey)
+	sigCache.Add(hash, sig, recovered)
+	return recovered == miner
+}
+
+// BatchVerifyMinerSigs verifies the miner qr signature of every header in
+// the segment, grouping the work across GOMAXPROCS goroutines so
+// InsertChain can prevalidate an entire chain segment's signatures in
+// parallel before entering its serial state-transition loop. The returned
+// slice is indexed the same as headers; an entry is nil when that
+// header's signature is missing (genesis/block 1, which carry none) or
+// valid.
+func (v *BlockValidator) BatchVerifyMinerSigs(headers []*types.Header) []error {
+	errs := make([]error, len(headers))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(headers) {
+		workers = len(headers)
+	}
+	if workers < 1 {
+		return errs
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(headers); i += workers {
+				header := headers[i]
+				if header.Number.Int64() <= 1 {
+					continue
+				}
+				if len(header.MinerQrSignature) != minerlist.PreQrLength {
+					errs[i] = v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: bad length", consensus.ErrInvalidMinerQr))
+					continue
+				}
+				qr := common.BytesToHash(header.MinerQrSignature[65:])
+				if !VerifySig(header.MinerQrSignature[:65], qr, header.Coinbase) {
+					errs[i] = v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: bad signature", consensus.ErrInvalidMinerQr))
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	return errs
 }
@@ -0,0 +1,209 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/consensus"
+	"github.com/usechain/go-usechain/contracts/minerlist"
+	"github.com/usechain/go-usechain/core/state"
+	"github.com/usechain/go-usechain/core/types"
+)
+
+// ValidationResult is the outcome of a ValidateAsync call: the first error
+// by check order (not completion order, so results stay deterministic
+// when several checks fail at once) plus a per-check timing breakdown.
+type ValidationResult struct {
+	Err     error
+	Timings map[string]time.Duration
+}
+
+// ValidateAsync runs the independent hashing and signature checks that
+// ValidateBody/ValidateState/ValidateMiner perform serially - uncle hash,
+// tx trie root, receipt trie root, bloom, intermediate state root, and
+// ecrecover of the miner qr signature - on a worker pool, and joins the
+// results. When multiple checks fail, the error reported is always the
+// one from the earliest-numbered check below, regardless of which
+// goroutine finishes first.
+func (v *BlockValidator) ValidateAsync(block, parent *types.Block, statedb *state.StateDB, receipts types.Receipts, usedGas uint64) *ValidationResult {
+	header := block.Header()
+
+	checks := []struct {
+		name string
+		fn   func() error
+	}{
+		{"uncleHash", func() error {
+			if hash := types.CalcUncleHash(block.Uncles()); hash != header.UncleHash {
+				return fmt.Errorf("uncle root hash mismatch: have %x, want %x", hash, header.UncleHash)
+			}
+			return nil
+		}},
+		{"txRoot", func() error {
+			if hash := types.DeriveSha(block.Transactions()); hash != header.TxHash {
+				return fmt.Errorf("transaction root hash mismatch: have %x, want %x", hash, header.TxHash)
+			}
+			return nil
+		}},
+		{"blobSidecarStripped", func() error {
+			// Mirrors the check ValidateBody makes: blocks only ever
+			// propagate the stripped form of a blob transaction.
+			for _, tx := range block.Transactions() {
+				if tx.BlobTxSidecar() != nil {
+					return fmt.Errorf("transaction %x retains blob sidecar in imported block", tx.Hash())
+				}
+			}
+			return nil
+		}},
+		{"blobKZG", func() error {
+			// Mirrors the check ValidateBody makes: the KZG/versioned-hash
+			// checks ValidateBlobs performs against the sidecars the
+			// registered BlobPool stashed.
+			return v.validateBlobSidecars(block)
+		}},
+		{"receiptRoot", func() error {
+			if receiptSha := types.DeriveSha(receipts); receiptSha != header.ReceiptHash {
+				return fmt.Errorf("invalid receipt root hash (remote: %x local: %x)", header.ReceiptHash, receiptSha)
+			}
+			return nil
+		}},
+		{"bloom", func() error {
+			if rbloom := types.CreateBloom(receipts); rbloom != header.Bloom {
+				return fmt.Errorf("invalid bloom (remote: %x  local: %x)", header.Bloom, rbloom)
+			}
+			return nil
+		}},
+		{"stateRoot", func() error {
+			if block.GasUsed() != usedGas {
+				return fmt.Errorf("invalid gas used (remote: %d local: %d)", block.GasUsed(), usedGas)
+			}
+			if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
+				return fmt.Errorf("invalid merkle root (remote: %x local: %x)", header.Root, root)
+			}
+			return nil
+		}},
+		{"minerQrSig", func() error {
+			if header.Number.Int64() <= 1 {
+				return nil
+			}
+			if len(header.MinerQrSignature) != minerlist.PreQrLength {
+				return v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: bad length", consensus.ErrInvalidMinerQr))
+			}
+			qr := common.BytesToHash(header.MinerQrSignature[65:])
+			if !VerifySig(header.MinerQrSignature[:65], qr, header.Coinbase) {
+				return v.reportMinerFailure(consensus.ReasonInvalidMinerQr, header.Coinbase, header.Number.Uint64(), fmt.Errorf("%w: bad signature", consensus.ErrInvalidMinerQr))
+			}
+			return nil
+		}},
+	}
+
+	type checkResult struct {
+		err     error
+		elapsed time.Duration
+	}
+	results := make([]checkResult, len(checks))
+
+	var wg sync.WaitGroup
+	wg.Add(len(checks))
+	for i, c := range checks {
+		go func(i int, fn func() error) {
+			defer wg.Done()
+			start := time.Now()
+			err := fn()
+			results[i] = checkResult{err: err, elapsed: time.Since(start)}
+		}(i, c.fn)
+	}
+	wg.Wait()
+
+	result := &ValidationResult{Timings: make(map[string]time.Duration, len(checks))}
+	for i, c := range checks {
+		result.Timings[c.name] = results[i].elapsed
+		if results[i].err != nil && result.Err == nil {
+			result.Err = results[i].err
+		}
+	}
+	return result
+}
+
+// PipelineSegment bundles the per-block inputs ValidatePipeline needs
+// beyond the block itself.
+type PipelineSegment struct {
+	Block    *types.Block
+	Parent   *types.Block
+	StateDB  *state.StateDB
+	Receipts types.Receipts
+	UsedGas  uint64
+}
+
+// ValidatePipeline validates a contiguous run of segments, overlapping the
+// body validation of segment N+1 with the state validation of segment N,
+// since body validation does not depend on N's post-state. The segments
+// are assumed to already be a known-linkable run (the caller has
+// established that via the normal ValidateBody chain-membership check
+// before handing them to the pipeline), so the body stage here skips only
+// the v.bc chain-membership checks ValidateBody performs - it still runs
+// the consensus-engine uncle verification (v.engine.VerifyUncles) that a
+// segment's mere contiguity can't stand in for, plus the content checks
+// validateBodyContent performs (uncle root, transaction root, blob
+// sidecar stripping). The bounded channel between the two stages provides
+// backpressure: it buffers at most `depth` finished body results, so the
+// body stage can run at most depth+1 segments ahead of the state stage that
+// consumes its results (one more finished result waits on the full channel).
+func (v *BlockValidator) ValidatePipeline(segments []PipelineSegment, depth int) error {
+	if depth <= 0 {
+		depth = 1
+	}
+	type bodyResult struct {
+		err error
+	}
+	bodies := make(chan bodyResult, depth)
+
+	go func() {
+		defer close(bodies)
+		for _, seg := range segments {
+			bodies <- bodyResult{err: v.validatePipelineBody(seg)}
+		}
+	}()
+
+	for _, seg := range segments {
+		br := <-bodies
+		if br.err != nil {
+			return br.err
+		}
+		if err := v.ValidateState(seg.Block, seg.Parent, seg.StateDB, seg.Receipts, seg.UsedGas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePipelineBody is ValidatePipeline's per-segment body check: the
+// same v.engine.VerifyUncles call ValidateBody makes (skipped only when
+// no engine is registered, e.g. a validator built for a benchmark/test
+// rather than real chain import), followed by validateBodyContent.
+func (v *BlockValidator) validatePipelineBody(seg PipelineSegment) error {
+	if v.engine != nil {
+		state, _ := v.bc.State()
+		if err := v.engine.VerifyUncles(v.bc, seg.Block, state); err != nil {
+			return err
+		}
+	}
+	return v.validateBodyContent(seg.Block)
+}
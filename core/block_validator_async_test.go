@@ -0,0 +1,66 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/core/state"
+	"github.com/usechain/go-usechain/core/types"
+	"github.com/usechain/go-usechain/params"
+)
+
+// TestValidateAsyncReturnsEarliestCheckOnSimultaneousFailures builds a
+// block that fails both the first check (uncleHash) and a much later one
+// (stateRoot), and asserts ValidateAsync always reports the uncleHash
+// failure - the earliest-numbered check - regardless of which goroutine
+// happens to finish first.
+func TestValidateAsyncReturnsEarliestCheckOnSimultaneousFailures(t *testing.T) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(nil))
+	if err != nil {
+		t.Fatalf("build statedb: %v", err)
+	}
+	receipts := types.Receipts{}
+	header := &types.Header{
+		Number:      big.NewInt(1),
+		ParentHash:  common.Hash{},
+		UncleHash:   common.BytesToHash([]byte("wrong uncle hash")),
+		TxHash:      types.DeriveSha(types.Transactions{}),
+		ReceiptHash: types.DeriveSha(receipts),
+		Bloom:       types.CreateBloom(receipts),
+		Root:        statedb.IntermediateRoot(false),
+	}
+	block := types.NewBlockWithHeader(header)
+	parent := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+
+	v := &BlockValidator{config: params.TestChainConfig}
+	// usedGas deliberately doesn't match block.GasUsed(), so the
+	// much-later "stateRoot" check fails at the same time as "uncleHash".
+	const wrongUsedGas = 1
+	for i := 0; i < 20; i++ {
+		res := v.ValidateAsync(block, parent, statedb, receipts, wrongUsedGas)
+		if res.Err == nil {
+			t.Fatalf("expected an error")
+		}
+		if !strings.Contains(res.Err.Error(), "uncle root hash mismatch") {
+			t.Fatalf("ValidateAsync returned %q, want the uncleHash check's error", res.Err)
+		}
+	}
+}
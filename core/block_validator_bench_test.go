@@ -0,0 +1,131 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/core/state"
+	"github.com/usechain/go-usechain/core/types"
+	"github.com/usechain/go-usechain/crypto"
+	"github.com/usechain/go-usechain/params"
+)
+
+// benchChain builds a segment of n linked, minimally-populated blocks
+// together with the statedb/receipts/usedGas inputs ValidateAsync and
+// ValidatePipeline need, purely to drive their throughput at the 1k-block
+// scale this request asks benchmarks to demonstrate.
+func benchChain(b *testing.B, n int) ([]*types.Block, []*state.StateDB, []types.Receipts, []uint64) {
+	b.Helper()
+
+	blocks := make([]*types.Block, n)
+	statedbs := make([]*state.StateDB, n)
+	receiptsList := make([]types.Receipts, n)
+	usedGas := make([]uint64, n)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatalf("generate key: %v", err)
+	}
+	miner := crypto.PubkeyToAddress(key.PublicKey)
+
+	parentHash := common.Hash{}
+	for i := 0; i < n; i++ {
+		receipts := types.Receipts{}
+
+		// Blocks past number 1 need a valid miner qr signature (the 65-byte
+		// signature followed by the signed qr hash) to pass minerQrSig.
+		qr := common.BytesToHash(big.NewInt(int64(i + 1)).Bytes())
+		sig, err := crypto.Sign(qr.Bytes(), key)
+		if err != nil {
+			b.Fatalf("sign: %v", err)
+		}
+
+		statedb, err := state.New(common.Hash{}, state.NewDatabase(nil))
+		if err != nil {
+			b.Fatalf("build statedb: %v", err)
+		}
+
+		header := &types.Header{
+			Number:      big.NewInt(int64(i + 1)),
+			Time:        big.NewInt(int64(i) * int64(common.BlockInterval)),
+			ParentHash:  parentHash,
+			UncleHash:   types.CalcUncleHash(nil),
+			TxHash:      types.DeriveSha(types.Transactions{}),
+			ReceiptHash: types.DeriveSha(receipts),
+			Bloom:       types.CreateBloom(receipts),
+			Root:        statedb.IntermediateRoot(false),
+
+			Coinbase:         miner,
+			MinerQrSignature: append(sig, qr.Bytes()...),
+		}
+
+		block := types.NewBlockWithHeader(header)
+		blocks[i] = block
+		statedbs[i] = statedb
+		receiptsList[i] = receipts
+		usedGas[i] = 0
+
+		parentHash = block.Hash()
+	}
+	return blocks, statedbs, receiptsList, usedGas
+}
+
+// BenchmarkValidateAsync measures ValidateAsync's worker-pool fan-out
+// across a 1k-block import.
+func BenchmarkValidateAsync(b *testing.B) {
+	blocks, statedbs, receiptsList, usedGas := benchChain(b, 1000)
+	v := &BlockValidator{config: params.TestChainConfig}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 1; j < len(blocks); j++ {
+			res := v.ValidateAsync(blocks[j], blocks[j-1], statedbs[j], receiptsList[j], usedGas[j])
+			if res.Err != nil {
+				b.Fatalf("unexpected validation error: %v", res.Err)
+			}
+		}
+	}
+}
+
+// BenchmarkValidatePipeline measures ValidatePipeline's throughput over
+// the same 1k-block segment, overlapping body validation of block N+1
+// with state validation of block N.
+func BenchmarkValidatePipeline(b *testing.B) {
+	blocks, statedbs, receiptsList, usedGas := benchChain(b, 1000)
+	v := &BlockValidator{config: params.TestChainConfig}
+
+	segments := make([]PipelineSegment, len(blocks)-1)
+	for j := 1; j < len(blocks); j++ {
+		segments[j-1] = PipelineSegment{
+			Block:    blocks[j],
+			Parent:   blocks[j-1],
+			StateDB:  statedbs[j],
+			Receipts: receiptsList[j],
+			UsedGas:  usedGas[j],
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.ValidatePipeline(segments, 8); err != nil {
+			b.Fatalf("unexpected validation error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/core/blobpool"
+	"github.com/usechain/go-usechain/core/types"
+)
+
+// RegisterBlobPool wires a BlobPool into the validator, so blob sidecars
+// can be pooled as blocks are built and inserted, and reinjected on a
+// reorg. Until this is called, PrepareBlockTransactions/MarkBlobsIncluded
+// are no-ops and ReorgBlobs only purges the signature cache.
+func (v *BlockValidator) RegisterBlobPool(pool *blobpool.BlobPool) {
+	v.blobs = pool
+}
+
+// PrepareBlockTransactions strips the sidecar from every blob transaction
+// in txs, stashing each one in the registered BlobPool so it can still be
+// found once the block only carries the stripped form. The miner calls
+// this right before committing txs into a block's header/body.
+func (v *BlockValidator) PrepareBlockTransactions(txs []*types.Transaction) []*types.Transaction {
+	if v.blobs == nil {
+		return txs
+	}
+	out := make([]*types.Transaction, len(txs))
+	for i, tx := range txs {
+		if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+			v.blobs.Add(tx.Hash(), sidecar)
+			out[i] = tx.WithoutBlobSidecar()
+			continue
+		}
+		out[i] = tx
+	}
+	return out
+}
+
+// MarkBlobsIncluded moves every blob transaction's sidecar from the pool's
+// pending set into limbo once block has passed validation and is about to
+// be inserted, keyed off the block number, then shifts the pool's limbo
+// window to this new head so sidecars included more than limboSlots blocks
+// ago are evicted instead of retained forever.
+func (v *BlockValidator) MarkBlobsIncluded(block *types.Block) {
+	if v.blobs == nil {
+		return
+	}
+	for _, tx := range block.Transactions() {
+		if len(tx.BlobVersionedHashes()) > 0 {
+			v.blobs.Included(tx.Hash(), block.NumberU64())
+		}
+	}
+	v.blobs.Shift(block.NumberU64())
+}
+
+// ReorgBlobs is the validator's reorg hook: it evicts the signature cache
+// (recoveries keyed off the abandoned side of the chain are no longer
+// useful) and re-admits the sidecars of blob transactions surfaced by the
+// reorg back into the pool's pending set, so they can be rebroadcast with
+// their blobs intact. A transaction whose limbo window already expired is
+// reported in lost: it cannot re-enter the pool without a fresh sidecar
+// supplied from outside.
+func (v *BlockValidator) ReorgBlobs(reorgedTxs []*types.Transaction) (recovered, lost []common.Hash) {
+	// Cached miner qr signature recoveries keyed off the abandoned side of
+	// the chain are no longer useful once a reorg happens.
+	PurgeSignatureCache()
+
+	if v.blobs == nil {
+		return nil, nil
+	}
+	for _, tx := range reorgedTxs {
+		if len(tx.BlobVersionedHashes()) == 0 {
+			continue
+		}
+		hash := tx.Hash()
+		if v.blobs.Reorged(hash) {
+			recovered = append(recovered, hash)
+		} else {
+			lost = append(lost, hash)
+		}
+	}
+	return recovered, lost
+}
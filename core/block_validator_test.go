@@ -0,0 +1,66 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/consensus"
+)
+
+type recordingPunishmentReporter struct {
+	got *consensus.MinerValidationError
+}
+
+func (r *recordingPunishmentReporter) ReportMinerValidationFailure(err *consensus.MinerValidationError) {
+	r.got = err
+}
+
+func TestReportMinerFailureNotifiesRegisteredReporter(t *testing.T) {
+	v := &BlockValidator{}
+	reporter := &recordingPunishmentReporter{}
+	v.RegisterPunishmentReporter(reporter)
+
+	coinbase := common.BytesToAddress([]byte("miner"))
+	err := v.reportMinerFailure(consensus.ReasonPunishedMiner, coinbase, 123, consensus.ErrPunishedMiner)
+
+	if !errors.Is(err, consensus.ErrPunishedMiner) {
+		t.Fatalf("reportMinerFailure's returned error does not unwrap to ErrPunishedMiner")
+	}
+	if reporter.got == nil {
+		t.Fatalf("expected the registered reporter to be invoked")
+	}
+	if reporter.got.Reason != consensus.ReasonPunishedMiner {
+		t.Errorf("Reason = %v, want %v", reporter.got.Reason, consensus.ReasonPunishedMiner)
+	}
+	if reporter.got.Coinbase != coinbase {
+		t.Errorf("Coinbase = %v, want %v", reporter.got.Coinbase, coinbase)
+	}
+	if reporter.got.Number != 123 {
+		t.Errorf("Number = %v, want 123", reporter.got.Number)
+	}
+}
+
+func TestReportMinerFailureWithoutReporterStillReturnsError(t *testing.T) {
+	v := &BlockValidator{}
+	err := v.reportMinerFailure(consensus.ReasonInvalidMiner, common.BytesToAddress([]byte("miner")), 1, consensus.ErrInvalidMiner)
+	if !errors.Is(err, consensus.ErrInvalidMiner) {
+		t.Fatalf("expected the error to still unwrap to ErrInvalidMiner when no reporter is registered")
+	}
+}
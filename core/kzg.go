@@ -0,0 +1,38 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+
+	"github.com/usechain/go-usechain/core/types"
+)
+
+// ErrKZGBackendUnavailable is returned by ValidateBlobs when kzgVerifyProof
+// has no pairing backend wired in, so a blob proof cannot be checked at
+// all. This is a hard validation failure, never an implicit accept.
+var ErrKZGBackendUnavailable = errors.New("no KZG backend configured for blob proof verification")
+
+// kzgVerifyProof checks a KZG opening proof for a blob against its
+// commitment. It is a package variable rather than a hard dependency so a
+// concrete pairing backend can be wired in at init time without this
+// package importing it directly. The zero-value implementation below
+// refuses every proof with ErrKZGBackendUnavailable until something
+// replaces it - it must never be mistaken for "proofs are valid".
+var kzgVerifyProof = func(blob types.Blob, commitment types.BlobKZGCommitment, proof types.BlobKZGProof) (bool, error) {
+	return false, ErrKZGBackendUnavailable
+}
@@ -0,0 +1,128 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/usechain/go-usechain/common"
+)
+
+// sigCacheKey identifies a recovered signature by the signed hash and the
+// first 65 bytes of the signature (the recoverable r||s||v portion).
+type sigCacheKey struct {
+	hash common.Hash
+	sig  [65]byte
+}
+
+// SignatureCache is an LRU of recovered miner qr signatures, keyed by
+// (hash, sig) so VerifySig can skip ecrecover for a signature it has
+// already checked. It is safe for concurrent use.
+type SignatureCache struct {
+	limit int
+
+	mu    sync.Mutex
+	items map[sigCacheKey]*list.Element
+	order *list.List // front = most recently used
+
+	hits   uint64
+	misses uint64
+}
+
+type sigCacheEntry struct {
+	key     sigCacheKey
+	address common.Address
+}
+
+// NewSignatureCache returns a SignatureCache holding at most limit entries;
+// a limit below 1 is raised to 1.
+func NewSignatureCache(limit int) *SignatureCache {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &SignatureCache{
+		limit: limit,
+		items: make(map[sigCacheKey]*list.Element),
+		order: list.New(),
+	}
+}
+
+func toSigCacheKey(hash common.Hash, sig []byte) sigCacheKey {
+	var key sigCacheKey
+	key.hash = hash
+	copy(key.sig[:], sig)
+	return key
+}
+
+// Get returns the address recovered for (hash, sig), if cached.
+func (c *SignatureCache) Get(hash common.Hash, sig []byte) (common.Address, bool) {
+	key := toSigCacheKey(hash, sig)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return common.Address{}, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	c.order.MoveToFront(elem)
+	return elem.Value.(*sigCacheEntry).address, true
+}
+
+// Add records the address recovered for (hash, sig), evicting the least
+// recently used entry if the cache is full.
+func (c *SignatureCache) Add(hash common.Hash, sig []byte, address common.Address) {
+	key := toSigCacheKey(hash, sig)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*sigCacheEntry).address = address
+		c.order.MoveToFront(elem)
+		return
+	}
+	elem := c.order.PushFront(&sigCacheEntry{key: key, address: address})
+	c.items[key] = elem
+	if c.order.Len() > c.limit {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*sigCacheEntry).key)
+	}
+}
+
+// Purge evicts every entry, used when a reorg makes cached recoveries for
+// the abandoned side of the chain not worth keeping around.
+func (c *SignatureCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[sigCacheKey]*list.Element)
+	c.order.Init()
+}
+
+// HitRate returns the fraction of Get calls that were served from cache.
+func (c *SignatureCache) HitRate() float64 {
+	hits := atomic.LoadUint64(&c.hits)
+	misses := atomic.LoadUint64(&c.misses)
+	total := hits + misses
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
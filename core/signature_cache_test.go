@@ -0,0 +1,164 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/core/types"
+	"github.com/usechain/go-usechain/crypto"
+)
+
+func TestSignatureCacheGetAddPurge(t *testing.T) {
+	c := NewSignatureCache(2)
+	hash := common.BytesToHash([]byte("hash"))
+	sig := make([]byte, 65)
+	addr := common.BytesToAddress([]byte("addr"))
+
+	if _, ok := c.Get(hash, sig); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+	c.Add(hash, sig, addr)
+	got, ok := c.Get(hash, sig)
+	if !ok || got != addr {
+		t.Fatalf("Get after Add = %v, %v; want %v, true", got, ok, addr)
+	}
+
+	c.Purge()
+	if _, ok := c.Get(hash, sig); ok {
+		t.Fatalf("expected miss after Purge")
+	}
+}
+
+func TestSignatureCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewSignatureCache(2)
+	sig := make([]byte, 65)
+	h1 := common.BytesToHash([]byte("h1"))
+	h2 := common.BytesToHash([]byte("h2"))
+	h3 := common.BytesToHash([]byte("h3"))
+	a1 := common.BytesToAddress([]byte("a1"))
+	a2 := common.BytesToAddress([]byte("a2"))
+	a3 := common.BytesToAddress([]byte("a3"))
+
+	c.Add(h1, sig, a1)
+	c.Add(h2, sig, a2)
+	// Touch h1 so h2 becomes the least recently used entry.
+	if _, ok := c.Get(h1, sig); !ok {
+		t.Fatalf("expected hit for h1")
+	}
+	c.Add(h3, sig, a3)
+
+	if _, ok := c.Get(h2, sig); ok {
+		t.Fatalf("expected h2 to have been evicted")
+	}
+	if _, ok := c.Get(h1, sig); !ok {
+		t.Fatalf("expected h1 to still be cached")
+	}
+	if _, ok := c.Get(h3, sig); !ok {
+		t.Fatalf("expected h3 to be cached")
+	}
+}
+
+// signQr signs hash with a fresh key and returns the 65-byte signature
+// plus the address it recovers to.
+func signQr(t *testing.T, hash common.Hash) ([]byte, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return sig, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestVerifySigCacheHitMiss(t *testing.T) {
+	PurgeSignatureCache()
+	hash := common.BytesToHash([]byte("qr"))
+	sig, addr := signQr(t, hash)
+
+	before := SignatureCacheHitRate()
+	if !VerifySig(sig, hash, addr) {
+		t.Fatalf("expected valid signature to verify on first (miss) call")
+	}
+	if !VerifySig(sig, hash, addr) {
+		t.Fatalf("expected valid signature to verify on second (hit) call")
+	}
+	if VerifySig(sig, hash, common.BytesToAddress([]byte("someone else"))) {
+		t.Fatalf("expected verification against the wrong miner to fail even when cached")
+	}
+	if SignatureCacheHitRate() <= before {
+		t.Fatalf("expected hit rate to increase after a cached call, got %v (was %v)", SignatureCacheHitRate(), before)
+	}
+}
+
+func TestPurgeSignatureCacheEvictsOnReorg(t *testing.T) {
+	PurgeSignatureCache()
+	hash := common.BytesToHash([]byte("reorg-qr"))
+	sig, addr := signQr(t, hash)
+
+	if !VerifySig(sig, hash, addr) {
+		t.Fatalf("expected valid signature to verify")
+	}
+	if _, ok := sigCache.Get(hash, sig); !ok {
+		t.Fatalf("expected signature to be cached before purge")
+	}
+
+	PurgeSignatureCache()
+	if _, ok := sigCache.Get(hash, sig); ok {
+		t.Fatalf("expected signature cache to be empty after PurgeSignatureCache")
+	}
+}
+
+func TestBatchVerifyMinerSigs(t *testing.T) {
+	v := &BlockValidator{}
+
+	goodHash := common.BytesToHash([]byte("good"))
+	goodSig, goodAddr := signQr(t, goodHash)
+
+	headers := []*types.Header{
+		// Genesis/block 1 carry no signature and must be skipped.
+		{Number: big.NewInt(1)},
+		// A valid signature must not produce an error.
+		{
+			Number:           big.NewInt(2),
+			Coinbase:         goodAddr,
+			MinerQrSignature: append(append([]byte{}, goodSig...), goodHash.Bytes()...),
+		},
+		// A bad-length signature must be reported as an error.
+		{
+			Number:           big.NewInt(3),
+			Coinbase:         goodAddr,
+			MinerQrSignature: []byte{0x01, 0x02},
+		},
+	}
+
+	errs := v.BatchVerifyMinerSigs(headers)
+	if errs[0] != nil {
+		t.Fatalf("expected no error for block 1, got %v", errs[0])
+	}
+	if errs[1] != nil {
+		t.Fatalf("expected no error for a valid signature, got %v", errs[1])
+	}
+	if errs[2] == nil {
+		t.Fatalf("expected an error for a bad-length signature")
+	}
+}
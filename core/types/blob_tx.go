@@ -0,0 +1,132 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/usechain/go-usechain/common"
+)
+
+// BlobTxType is the EIP-4844 transaction type byte.
+const BlobTxType = 0x03
+
+// blobCommitmentVersionKZG is the version byte prepended to a blob's KZG
+// commitment hash to derive its versioned hash.
+const blobCommitmentVersionKZG byte = 0x01
+
+// ErrMismatchedBlobSidecar is returned when a blob sidecar's blob,
+// commitment and proof counts don't all agree with the number of versioned
+// hashes declared by the transaction it accompanies.
+var ErrMismatchedBlobSidecar = errors.New("blob sidecar length mismatch")
+
+// BlobKZGCommitment and BlobKZGProof are raw KZG curve points as defined by
+// EIP-4844. Their cryptographic validity is checked by the KZG backend
+// wired into core.BlockValidator, not by this package.
+type BlobKZGCommitment [48]byte
+type BlobKZGProof [48]byte
+
+// Blob is a single 4844 blob of field elements.
+type Blob [131072]byte
+
+// BlobTxSidecar carries the blobs, commitments and proofs that accompany a
+// BlobTx while it propagates through the mempool. A sidecar never travels
+// inside an imported block body; see BlockValidator.ValidateBody.
+type BlobTxSidecar struct {
+	Blobs       []Blob
+	Commitments []BlobKZGCommitment
+	Proofs      []BlobKZGProof
+}
+
+// BlobTx is an EIP-4844 transaction. Sidecar is nil once the transaction
+// has been included in a block and stripped for propagation.
+type BlobTx struct {
+	ChainID             *big.Int
+	Nonce               uint64
+	GasTipCap           *big.Int
+	GasFeeCap           *big.Int
+	Gas                 uint64
+	To                  common.Address
+	Value               *big.Int
+	Data                []byte
+	BlobFeeCap          *big.Int
+	BlobVersionedHashes []common.Hash
+	V, R, S             *big.Int
+
+	Sidecar *BlobTxSidecar `rlp:"-"`
+}
+
+// WithoutSidecar returns a shallow copy of tx with its sidecar stripped.
+// The miner calls this before committing a blob transaction into a block,
+// since blocks only ever propagate the stripped form.
+func (tx *BlobTx) WithoutSidecar() *BlobTx {
+	cpy := *tx
+	cpy.Sidecar = nil
+	return &cpy
+}
+
+// txType implements TxData.
+func (tx *BlobTx) txType() byte { return BlobTxType }
+
+// copy implements TxData, deep-copying the sidecar so stripping one
+// Transaction's copy never mutates another's.
+func (tx *BlobTx) copy() TxData {
+	cpy := *tx
+	cpy.Data = append([]byte(nil), tx.Data...)
+	cpy.BlobVersionedHashes = append([]common.Hash(nil), tx.BlobVersionedHashes...)
+	for _, f := range []**big.Int{&cpy.ChainID, &cpy.GasTipCap, &cpy.GasFeeCap, &cpy.Value, &cpy.BlobFeeCap, &cpy.V, &cpy.R, &cpy.S} {
+		if *f != nil {
+			*f = new(big.Int).Set(*f)
+		}
+	}
+	if tx.Sidecar != nil {
+		cpy.Sidecar = &BlobTxSidecar{
+			Blobs:       append([]Blob(nil), tx.Sidecar.Blobs...),
+			Commitments: append([]BlobKZGCommitment(nil), tx.Sidecar.Commitments...),
+			Proofs:      append([]BlobKZGProof(nil), tx.Sidecar.Proofs...),
+		}
+	}
+	return &cpy
+}
+
+func (tx *BlobTx) nonce() uint64       { return tx.Nonce }
+func (tx *BlobTx) gas() uint64         { return tx.Gas }
+func (tx *BlobTx) gasFeeCap() *big.Int { return tx.GasFeeCap }
+func (tx *BlobTx) gasTipCap() *big.Int { return tx.GasTipCap }
+
+// gasPrice returns GasFeeCap: like every EIP-1559-style transaction, a
+// BlobTx has no single gas price, and GasFeeCap is the most it will ever
+// pay per unit of gas.
+func (tx *BlobTx) gasPrice() *big.Int { return tx.GasFeeCap }
+func (tx *BlobTx) to() common.Address { return tx.To }
+func (tx *BlobTx) value() *big.Int    { return tx.Value }
+func (tx *BlobTx) data() []byte       { return tx.Data }
+
+func (tx *BlobTx) rawSignatureValues() (v, r, s *big.Int) {
+	return tx.V, tx.R, tx.S
+}
+
+// VersionedHash derives the EIP-4844 versioned hash for a single KZG
+// commitment: the commitment's sha256 digest with its first byte replaced
+// by the KZG version byte.
+func VersionedHash(commitment BlobKZGCommitment) common.Hash {
+	h := sha256.Sum256(commitment[:])
+	h[0] = blobCommitmentVersionKZG
+	return common.Hash(h)
+}
@@ -0,0 +1,158 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"sync/atomic"
+
+	"github.com/usechain/go-usechain/common"
+	"github.com/usechain/go-usechain/crypto"
+	"github.com/usechain/go-usechain/rlp"
+)
+
+// This file is deliberately named tx_envelope.go rather than
+// transaction.go: core/block_validator.go already calls
+// block.Transactions() and types.DeriveSha(block.Transactions()), which
+// depend on a full Transaction/Transactions/DeriveSha implementation this
+// series doesn't add. TxData/Transaction below are a minimal EIP-2718
+// envelope scoped to what BlockValidator's blob checks need, not the
+// node's real signer/txpool/RPC-facing transaction type, so they're kept
+// out of that type's canonical filename to avoid colliding with it.
+//
+// TxData is the underlying data of a typed transaction. BlobTx (see
+// blob_tx.go) is the only implementation in this package so far.
+type TxData interface {
+	txType() byte
+	copy() TxData
+
+	nonce() uint64
+	gas() uint64
+	gasPrice() *big.Int
+	gasTipCap() *big.Int
+	gasFeeCap() *big.Int
+	to() common.Address
+	value() *big.Int
+	data() []byte
+	rawSignatureValues() (v, r, s *big.Int)
+}
+
+// Transaction is the typed-transaction envelope: a thin wrapper around a
+// concrete TxData implementation plus a lazily-computed, cached hash.
+type Transaction struct {
+	inner TxData
+	hash  atomic.Value
+}
+
+// NewTx wraps inner in a Transaction, deep-copying it first so later
+// mutations to the caller's value can't leak into the transaction.
+func NewTx(inner TxData) *Transaction {
+	return &Transaction{inner: inner.copy()}
+}
+
+// Type returns the transaction's EIP-2718 type byte.
+func (tx *Transaction) Type() byte {
+	return tx.inner.txType()
+}
+
+// Nonce returns the sender account nonce of the transaction.
+func (tx *Transaction) Nonce() uint64 { return tx.inner.nonce() }
+
+// Gas returns the gas limit of the transaction.
+func (tx *Transaction) Gas() uint64 { return tx.inner.gas() }
+
+// GasPrice returns the gas price of the transaction.
+func (tx *Transaction) GasPrice() *big.Int { return new(big.Int).Set(tx.inner.gasPrice()) }
+
+// GasTipCap returns the gasTipCap per gas of the transaction.
+func (tx *Transaction) GasTipCap() *big.Int { return new(big.Int).Set(tx.inner.gasTipCap()) }
+
+// GasFeeCap returns the fee cap per gas of the transaction.
+func (tx *Transaction) GasFeeCap() *big.Int { return new(big.Int).Set(tx.inner.gasFeeCap()) }
+
+// To returns the recipient address of the transaction.
+func (tx *Transaction) To() common.Address { return tx.inner.to() }
+
+// Value returns the ether amount of the transaction.
+func (tx *Transaction) Value() *big.Int { return new(big.Int).Set(tx.inner.value()) }
+
+// Data returns the input data of the transaction.
+func (tx *Transaction) Data() []byte { return tx.inner.data() }
+
+// RawSignatureValues returns the V, R, S signature values of the
+// transaction. The return values should not be modified by the caller.
+func (tx *Transaction) RawSignatureValues() (v, r, s *big.Int) {
+	return tx.inner.rawSignatureValues()
+}
+
+// Hash returns the transaction hash, computing and caching it on first use.
+func (tx *Transaction) Hash() common.Hash {
+	if cached := tx.hash.Load(); cached != nil {
+		return cached.(common.Hash)
+	}
+	h := prefixedRlpHash(tx.inner.txType(), tx.inner)
+	tx.hash.Store(h)
+	return h
+}
+
+// BlobTxSidecar returns the blob sidecar carried by tx, or nil if tx is not
+// a BlobTx or its sidecar has already been stripped for propagation.
+func (tx *Transaction) BlobTxSidecar() *BlobTxSidecar {
+	blobTx, ok := tx.inner.(*BlobTx)
+	if !ok {
+		return nil
+	}
+	return blobTx.Sidecar
+}
+
+// BlobVersionedHashes returns the versioned hashes a BlobTx declares, or
+// nil for any other transaction type.
+func (tx *Transaction) BlobVersionedHashes() []common.Hash {
+	blobTx, ok := tx.inner.(*BlobTx)
+	if !ok {
+		return nil
+	}
+	return blobTx.BlobVersionedHashes
+}
+
+// WithoutBlobSidecar returns tx unchanged if it isn't a BlobTx, or
+// otherwise a copy with its sidecar stripped and the same cached hash -
+// EIP-4844 defines the transaction hash over the stripped form, so
+// stripping the sidecar never changes it. The miner calls this right
+// before committing a transaction into a block's header/body.
+func (tx *Transaction) WithoutBlobSidecar() *Transaction {
+	blobTx, ok := tx.inner.(*BlobTx)
+	if !ok {
+		return tx
+	}
+	stripped := NewTx(blobTx.WithoutSidecar())
+	stripped.hash.Store(tx.Hash())
+	return stripped
+}
+
+// prefixedRlpHash computes the transaction hash per EIP-2718: for the
+// legacy type (0), the hash is just the RLP encoding's Keccak256; for any
+// other type, the type byte is prepended before hashing, as mandated for
+// every typed transaction including BlobTx.
+func prefixedRlpHash(txType byte, x interface{}) (h common.Hash) {
+	if txType == 0 {
+		data, _ := rlp.EncodeToBytes(x)
+		return crypto.Keccak256Hash(data)
+	}
+	data, _ := rlp.EncodeToBytes(x)
+	return crypto.Keccak256Hash(append([]byte{txType}, data...))
+}